@@ -0,0 +1,244 @@
+package logger
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OverflowPolicy decides what happens when the async queue is full.
+type OverflowPolicy int
+
+const (
+	// Block makes the caller wait for room in the queue. Preserves every
+	// log line at the cost of back-pressuring request handling.
+	Block OverflowPolicy = iota
+	// DropNewest discards the log line that just failed to enqueue,
+	// keeping everything already queued.
+	DropNewest
+	// DropOldest evicts the oldest queued line to make room for the new
+	// one, favoring recent log lines over old ones.
+	DropOldest
+)
+
+// Async configures the non-blocking, batched write pipeline. When Enabled,
+// formatted log buffers are handed to a bounded queue and written from a
+// single background goroutine instead of Config.Stream.Write being called
+// inline on the request goroutine.
+type Async struct {
+	// Enabled turns on the async pipeline. When false, logging behaves as
+	// if Async were never configured: every write is synchronous.
+	//
+	// Default: false
+	Enabled bool
+
+	// QueueSize is the number of formatted log buffers that may be
+	// queued before OverflowPolicy kicks in.
+	//
+	// Default: 1024
+	QueueSize int
+
+	// FlushInterval is the maximum time a batch is held before being
+	// written, even if MaxBatchBytes hasn't been reached.
+	//
+	// Default: 200 * time.Millisecond
+	FlushInterval time.Duration
+
+	// MaxBatchBytes is the size at which a batch is written immediately
+	// rather than waiting for FlushInterval.
+	//
+	// Default: 64 * 1024
+	MaxBatchBytes int
+
+	// OverflowPolicy decides what happens when QueueSize is reached.
+	//
+	// Default: Block
+	OverflowPolicy OverflowPolicy
+}
+
+// Stats reports cumulative async queue counters.
+type Stats struct {
+	// Enqueued is the number of log buffers accepted onto the queue.
+	Enqueued uint64
+	// Flushed is the number of log buffers written to Stream.
+	Flushed uint64
+	// Dropped is the number of log buffers discarded by OverflowPolicy.
+	Dropped uint64
+	// QueueDepth is the number of log buffers currently queued.
+	QueueDepth int
+}
+
+func asyncDefault(cfg Async) Async {
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 1024
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = 200 * time.Millisecond
+	}
+	if cfg.MaxBatchBytes <= 0 {
+		cfg.MaxBatchBytes = 64 * 1024
+	}
+	return cfg
+}
+
+// asyncWriter batches formatted log buffers and writes them to out from a
+// single background goroutine, so hot request paths never block on a
+// syscall.
+type asyncWriter struct {
+	out io.Writer
+	cfg Async
+
+	queue chan []byte
+	done  chan struct{}
+	wg    sync.WaitGroup
+
+	enqueued atomic.Uint64
+	flushed  atomic.Uint64
+	dropped  atomic.Uint64
+}
+
+// newAsyncWriter starts the background flush loop. Call close to flush
+// and stop it.
+func newAsyncWriter(out io.Writer, cfg Async) *asyncWriter {
+	cfg = asyncDefault(cfg)
+	w := &asyncWriter{
+		out:   out,
+		cfg:   cfg,
+		queue: make(chan []byte, cfg.QueueSize),
+		done:  make(chan struct{}),
+	}
+	w.wg.Add(1)
+	go w.loop()
+	return w
+}
+
+// write enqueues buf, applying OverflowPolicy when the queue is full. buf
+// is copied, so the caller's buffer may be reused immediately.
+func (w *asyncWriter) write(buf []byte) {
+	entry := append([]byte(nil), buf...)
+
+	select {
+	case w.queue <- entry:
+		w.enqueued.Add(1)
+		return
+	default:
+	}
+
+	switch w.cfg.OverflowPolicy {
+	case DropNewest:
+		w.dropped.Add(1)
+	case DropOldest:
+		select {
+		case <-w.queue:
+			w.dropped.Add(1)
+		default:
+		}
+		select {
+		case w.queue <- entry:
+			w.enqueued.Add(1)
+		default:
+			w.dropped.Add(1)
+		}
+	default: // Block
+		w.queue <- entry
+		w.enqueued.Add(1)
+	}
+}
+
+// loop drains the queue, coalescing buffers up to MaxBatchBytes or until
+// FlushInterval elapses, and writes each batch with a single Write call.
+func (w *asyncWriter) loop() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]byte, 0, w.cfg.MaxBatchBytes)
+	batched := 0
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if _, err := w.out.Write(batch); err == nil {
+			w.flushed.Add(uint64(batched))
+		}
+		batch = batch[:0]
+		batched = 0
+	}
+
+	for {
+		select {
+		case entry := <-w.queue:
+			batch = append(batch, entry...)
+			batched++
+			if len(batch) >= w.cfg.MaxBatchBytes {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-w.done:
+			for {
+				select {
+				case entry := <-w.queue:
+					batch = append(batch, entry...)
+					batched++
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// stats returns a snapshot of the queue counters.
+func (w *asyncWriter) stats() Stats {
+	return Stats{
+		Enqueued:   w.enqueued.Load(),
+		Flushed:    w.flushed.Load(),
+		Dropped:    w.dropped.Load(),
+		QueueDepth: len(w.queue),
+	}
+}
+
+// close flushes any queued buffers and stops the background goroutine.
+func (w *asyncWriter) close() {
+	close(w.done)
+	w.wg.Wait()
+}
+
+// AsyncStream wraps an io.Writer so writes are queued and flushed by a
+// background goroutine instead of blocking the caller on a syscall. New
+// wraps Config.Stream in one automatically when Config.Async.Enabled is
+// true; construct one directly with NewAsyncStream when you want an
+// async Sink.Stream, or want to hold onto Stats()/Close() yourself.
+type AsyncStream struct {
+	w *asyncWriter
+}
+
+// NewAsyncStream starts the background flush loop that writes to out.
+func NewAsyncStream(out io.Writer, cfg Async) *AsyncStream {
+	return &AsyncStream{w: newAsyncWriter(out, cfg)}
+}
+
+// Write enqueues p per cfg.OverflowPolicy. It never blocks on the
+// underlying writer's Write call.
+func (s *AsyncStream) Write(p []byte) (int, error) {
+	s.w.write(p)
+	return len(p), nil
+}
+
+// Stats reports cumulative queue counters.
+func (s *AsyncStream) Stats() Stats {
+	return s.w.stats()
+}
+
+// Close flushes any queued writes and stops the background goroutine. Call
+// it from your own app.Hooks().OnPreShutdown if you constructed an
+// AsyncStream yourself; New registers this automatically for the stream
+// it creates from Config.Async.
+func (s *AsyncStream) Close() error {
+	s.w.close()
+	return nil
+}
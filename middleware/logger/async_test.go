@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestAsyncStream_WritesAreFlushedOnClose(t *testing.T) {
+	var out strings.Builder
+	stream := NewAsyncStream(&out, Async{FlushInterval: time.Hour})
+
+	if _, err := stream.Write([]byte("line1\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if out.String() != "line1\n" {
+		t.Fatalf("out = %q, want %q", out.String(), "line1\n")
+	}
+	if stats := stream.Stats(); stats.Flushed != 1 || stats.Enqueued != 1 {
+		t.Fatalf("stats = %+v, want 1 enqueued and 1 flushed", stats)
+	}
+}
+
+// TestNew_AsyncEnabledFlushesOnShutdown registers the OnPreShutdown hook
+// with a real request handled over a real listener - app.Shutdown returns
+// ErrNotRunning without ever running a hook unless app.server has been set
+// by a prior Listen/Listener call, so a plain app.Test round-trip isn't
+// enough to exercise this path.
+func TestNew_AsyncEnabledFlushesOnShutdown(t *testing.T) {
+	var out strings.Builder
+
+	app := fiber.New()
+	app.Use(New(Config{
+		Stream: &out,
+		Format: "${status}\n",
+		Async:  Async{Enabled: true, FlushInterval: time.Hour},
+	}))
+	app.Get("/", func(c fiber.Ctx) error { return c.SendString("ok") })
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen: %v", err)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- app.Listener(ln) }()
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/")
+	if err != nil {
+		t.Fatalf("http.Get: %v", err)
+	}
+	resp.Body.Close()
+
+	if err := app.Shutdown(); err != nil {
+		t.Fatalf("app.Shutdown: %v", err)
+	}
+	if err := <-serveErr; err != nil {
+		t.Fatalf("app.Listener: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "200") {
+		t.Fatalf("shutdown did not flush queued log line, got %q", out.String())
+	}
+}
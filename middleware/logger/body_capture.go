@@ -0,0 +1,273 @@
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// truncatedSuffixFmt is appended to a captured body that was cut off at a
+// MaxRequestBytes/MaxResponseBytes boundary.
+const truncatedSuffixFmt = "…(truncated %d bytes)"
+
+// RedactRule replaces a value captured by ${reqbody}, ${resbody} or their
+// :json: path variants before it ever reaches Stream or Done.
+type RedactRule struct {
+	// Path is a dotted JSON path into the captured body (e.g.
+	// "user.ssn"). Rules apply only to captured bodies that parse as
+	// JSON; ${header:*} and ${query:*} values are not redacted.
+	Path string
+
+	// Replacement is one of:
+	//   "***"     - replace the value with a fixed mask
+	//   "len:N"   - replace the value with N asterisks
+	//   "hash:X"  - replace the value with X followed by a sha256 hex
+	//               digest of the original value, e.g. "hash:sha256:"
+	Replacement string
+}
+
+// BodyCapture configures request/response body logging for the
+// ${reqbody}, ${resbody}, ${reqbody:json:<path>} and ${resbody:json:<path>}
+// tags.
+type BodyCapture struct {
+	// MaxRequestBytes is the most request body bytes kept in the captured
+	// copy. The request body itself is not modified, so it remains
+	// available to downstream handlers.
+	//
+	// Default: 4096
+	MaxRequestBytes int
+
+	// MaxResponseBytes is the most response body bytes copied out of the
+	// response for logging. The full response is still written to the
+	// client; only the logged copy is capped. Has no effect on a response
+	// written via c.Response().SetBodyStreamWriter - those aren't captured
+	// at all, see captureResponseBody.
+	//
+	// Default: 4096
+	MaxResponseBytes int
+
+	// ContentTypes is the allowlist of content types (matched against the
+	// media type, ignoring parameters) eligible for capture, e.g.
+	// "application/json", "application/x-www-form-urlencoded". A
+	// response whose content type isn't allowlisted is never buffered,
+	// even partially.
+	//
+	// Default: ["application/json"]
+	ContentTypes []string
+
+	// Redact lists the fields to mask before a captured body is stored
+	// on Data, so PII never reaches Stream or a log file.
+	//
+	// Optional. Default: nil
+	Redact []RedactRule
+}
+
+// isZero reports whether cfg is the zero value, i.e. BodyCapture was never
+// configured. Capture is opt-in: a zero BodyCapture must never buffer a
+// body, so callers check this before applying bodyCaptureDefault.
+func (cfg BodyCapture) isZero() bool {
+	return cfg.MaxRequestBytes == 0 && cfg.MaxResponseBytes == 0 && cfg.ContentTypes == nil && cfg.Redact == nil
+}
+
+func bodyCaptureDefault(cfg BodyCapture) BodyCapture {
+	if cfg.MaxRequestBytes <= 0 {
+		cfg.MaxRequestBytes = 4096
+	}
+	if cfg.MaxResponseBytes <= 0 {
+		cfg.MaxResponseBytes = 4096
+	}
+	if cfg.ContentTypes == nil {
+		cfg.ContentTypes = []string{fiber.MIMEApplicationJSON}
+	}
+	return cfg
+}
+
+// contentTypeAllowed reports whether contentType's media type (ignoring any
+// "; charset=..." parameters) is present in allow.
+func contentTypeAllowed(contentType string, allow []string) bool {
+	media, _, _ := strings.Cut(contentType, ";")
+	media = strings.TrimSpace(media)
+	for _, want := range allow {
+		if strings.EqualFold(media, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// captureRequestBody returns up to cfg.MaxRequestBytes of c's request body,
+// redacted per cfg.Redact and marked if truncated. It returns nil when the
+// request's content type isn't allowlisted.
+func captureRequestBody(c fiber.Ctx, cfg BodyCapture) []byte {
+	if cfg.isZero() {
+		return nil
+	}
+	cfg = bodyCaptureDefault(cfg)
+	if !contentTypeAllowed(string(c.Request().Header.ContentType()), cfg.ContentTypes) {
+		return nil
+	}
+	full := redactBody(c.Body(), cfg.Redact)
+	body := full
+	truncated := len(full) > cfg.MaxRequestBytes
+	remaining := 0
+	if truncated {
+		body = full[:cfg.MaxRequestBytes:cfg.MaxRequestBytes]
+		remaining = len(full) - len(body)
+	}
+	return markTruncated(body, truncated, remaining)
+}
+
+// captureResponseBody returns up to cfg.MaxResponseBytes of c's response
+// body, redacted and marked if truncated.
+//
+// Handlers that stream their response via
+// c.Response().SetBodyStreamWriter are not captured: reading the body at
+// all here would mean draining the whole stream synchronously, in memory,
+// before the response has even been written to the client - exactly the
+// "response body is too big" / "streamed from slow external sources" cases
+// SetBodyStreamWriter exists for, and unbounded by MaxResponseBytes since
+// there is nothing buffered yet to cap. ${resbody} renders empty for a
+// streamed response.
+func captureResponseBody(c fiber.Ctx, cfg BodyCapture) []byte {
+	if cfg.isZero() {
+		return nil
+	}
+	cfg = bodyCaptureDefault(cfg)
+	if c.Response().IsBodyStream() {
+		return nil
+	}
+	if !contentTypeAllowed(string(c.Response().Header.ContentType()), cfg.ContentTypes) {
+		return nil
+	}
+	full := redactBody(c.Response().Body(), cfg.Redact)
+	out := full
+	truncated := len(full) > cfg.MaxResponseBytes
+	remaining := 0
+	if truncated {
+		out = full[:cfg.MaxResponseBytes:cfg.MaxResponseBytes]
+		remaining = len(full) - len(out)
+	}
+	return markTruncated(out, truncated, remaining)
+}
+
+// markTruncated appends the truncation marker when truncated is true.
+func markTruncated(body []byte, truncated bool, remaining int) []byte {
+	if !truncated || remaining <= 0 {
+		return body
+	}
+	return append(body, []byte(fmt.Sprintf(truncatedSuffixFmt, remaining))...)
+}
+
+// redactBody applies rules to body, treating it as JSON when it parses as
+// such and falling back to leaving non-JSON bodies untouched (there is no
+// safe generic way to redact a field inside an opaque byte stream).
+func redactBody(body []byte, rules []RedactRule) []byte {
+	if len(rules) == 0 || len(body) == 0 {
+		return body
+	}
+	var doc any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return body
+	}
+	for _, rule := range rules {
+		doc = redactJSONPath(doc, strings.Split(rule.Path, "."), rule.Replacement)
+	}
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+// redactJSONPath walks doc following path and replaces the value it finds
+// there, if any, returning the (possibly modified) document.
+func redactJSONPath(doc any, path []string, replacement string) any {
+	if len(path) == 0 {
+		return applyRedaction(doc, replacement)
+	}
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		return doc
+	}
+	key := path[0]
+	val, ok := obj[key]
+	if !ok {
+		return doc
+	}
+	obj[key] = redactJSONPath(val, path[1:], replacement)
+	return obj
+}
+
+// applyRedaction replaces value per the Replacement grammar documented on
+// RedactRule.
+func applyRedaction(value any, replacement string) any {
+	switch {
+	case replacement == "***":
+		return "***"
+	case strings.HasPrefix(replacement, "len:"):
+		n, err := strconv.Atoi(strings.TrimPrefix(replacement, "len:"))
+		if err != nil || n < 0 {
+			return "***"
+		}
+		return strings.Repeat("*", n)
+	case strings.HasPrefix(replacement, "hash:"):
+		prefix := strings.TrimPrefix(replacement, "hash:")
+		sum := sha256.Sum256([]byte(fmt.Sprint(value)))
+		return prefix + hex.EncodeToString(sum[:])
+	default:
+		return replacement
+	}
+}
+
+// extractJSONField reads the dotted path from a JSON body, returning its
+// string representation, or "" if the path doesn't resolve.
+func extractJSONField(body []byte, path string) string {
+	var doc any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return ""
+	}
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := doc.(map[string]any)
+		if !ok {
+			return ""
+		}
+		doc, ok = obj[key]
+		if !ok {
+			return ""
+		}
+	}
+	switch v := doc.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+}
+
+// tagReqBody implements ${reqbody} and ${reqbody:json:<path>}.
+func tagReqBody(output Buffer, _ fiber.Ctx, data *Data, extraParam string) (int, error) {
+	return writeBodyTag(output, data.ReqBody, extraParam)
+}
+
+// tagResBody implements ${resbody} and ${resbody:json:<path>}.
+func tagResBody(output Buffer, _ fiber.Ctx, data *Data, extraParam string) (int, error) {
+	return writeBodyTag(output, data.ResBody, extraParam)
+}
+
+func writeBodyTag(output Buffer, body []byte, extraParam string) (int, error) {
+	if path, ok := strings.CutPrefix(extraParam, "json"+paramSeparator); ok {
+		return output.WriteString(extractJSONField(body, path))
+	}
+	return output.Write(body)
+}
@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"bufio"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestBodyCapture_ReqBodyTagEndToEnd(t *testing.T) {
+	var out strings.Builder
+
+	app := fiber.New()
+	app.Use(New(Config{
+		Stream: &out,
+		Format: "${reqbody}\n",
+		BodyCapture: BodyCapture{
+			ContentTypes: []string{fiber.MIMEApplicationJSON},
+		},
+	}))
+	app.Post("/", func(c fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(`{"user":"alice"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if !strings.Contains(out.String(), `"user":"alice"`) {
+		t.Fatalf("captured request body missing from log line: %q", out.String())
+	}
+}
+
+func TestBodyCapture_StreamedResponseNotCaptured(t *testing.T) {
+	var resBody []byte
+
+	app := fiber.New()
+	app.Use(New(Config{
+		Done: func(_ fiber.Ctx, logString []byte) {},
+		LoggerFunc: func(c fiber.Ctx, data *Data, cfg Config) error {
+			resBody = data.ResBody
+			return nil
+		},
+		BodyCapture: BodyCapture{ContentTypes: []string{fiber.MIMEApplicationJSON}},
+	}))
+	app.Get("/", func(c fiber.Ctx) error {
+		c.Response().Header.SetContentType(fiber.MIMEApplicationJSON)
+		c.Response().SetBodyStreamWriter(func(w *bufio.Writer) {
+			_, _ = w.WriteString(`{"streamed":true}`)
+		})
+		return nil
+	})
+
+	if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil)); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resBody != nil {
+		t.Fatalf("streamed response body should not be captured, got %q", resBody)
+	}
+}
+
+func TestBodyCapture_DefaultsApplyWhenOnlyContentTypesSet(t *testing.T) {
+	cfg := bodyCaptureDefault(BodyCapture{ContentTypes: []string{fiber.MIMEApplicationJSON}})
+	if cfg.MaxRequestBytes != 4096 {
+		t.Fatalf("MaxRequestBytes = %d, want 4096", cfg.MaxRequestBytes)
+	}
+	if cfg.MaxResponseBytes != 4096 {
+		t.Fatalf("MaxResponseBytes = %d, want 4096", cfg.MaxResponseBytes)
+	}
+}
+
+func TestBodyCapture_ZeroValueNeverCaptures(t *testing.T) {
+	app := fiber.New()
+	body := c1Body(t, app)
+	if body != nil {
+		t.Fatalf("zero-value BodyCapture must never capture, got %q", body)
+	}
+}
+
+func c1Body(t *testing.T, app *fiber.App) []byte {
+	t.Helper()
+	var captured []byte
+	app.Use(New(Config{
+		Done: func(_ fiber.Ctx, logString []byte) {},
+		LoggerFunc: func(c fiber.Ctx, data *Data, cfg Config) error {
+			captured = data.ReqBody
+			return nil
+		},
+	}))
+	app.Post("/", func(c fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) })
+
+	req := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(`{"a":1}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	return captured
+}
@@ -31,6 +31,41 @@ type Config struct {
 	// Optional. Default: nil
 	Done func(c fiber.Ctx, logString []byte)
 
+	// Sinks fans log lines out to multiple destinations, each with its
+	// own Stream, Format, Encoding and status range, instead of stacking
+	// several logger middlewares. When empty, Stream/Format/Encoding are
+	// used as a single implicit sink.
+	//
+	// Optional. Default: nil
+	Sinks []Sink
+
+	// BodyCapture configures the ${reqbody}, ${resbody},
+	// ${reqbody:json:<path>} and ${resbody:json:<path>} tags. Leave the
+	// zero value to never capture bodies; those tags then render empty.
+	//
+	// Optional. Default: BodyCapture{}
+	BodyCapture BodyCapture
+
+	// Async, when Enabled, makes New wrap Stream in an AsyncStream so
+	// writes happen from a background goroutine instead of inline on the
+	// request path, and registers that AsyncStream's Close with the
+	// app's OnPreShutdown hook so queued lines are flushed before exit. Sink
+	// streams are unaffected; wrap one yourself with NewAsyncStream if
+	// you want a sink to be async too.
+	//
+	// Optional. Default: Async{} (synchronous writes)
+	Async Async
+
+	// Sampler decides whether a request that passed Skip is actually
+	// logged. It runs after Skip and before the log line is formatted, so
+	// sampled-out requests avoid formatting cost. 5xx responses and
+	// requests with c.Locals("logger.force")==true are always logged,
+	// regardless of what Sampler returns. See RateSampler, AlwaysSampler
+	// and RuleSampler.
+	//
+	// Optional. Default: AlwaysSampler()
+	Sampler Sampler
+
 	// tagFunctions defines the custom tag action
 	//
 	// Optional. Default: map[string]LogFunc
@@ -55,6 +90,17 @@ type Config struct {
 	// Optional. Default: [${time}] ${ip} ${status} - ${latency} ${method} ${path} ${error}
 	Format string
 
+	// Encoding controls how a log record is serialized: as the plain-text
+	// Format string, a single JSON object keyed by tag name, or a logfmt
+	// line. CustomTags are top-level keys; ${header:*}/${locals:*}/${query:*}
+	// expansions are promoted to nested objects under EncodingJSON and to
+	// dotted keys (e.g. header.X-Request-Id) under EncodingLogfmt. When
+	// an OTel span is present in c.Context(), trace_id and span_id are
+	// added automatically.
+	//
+	// Optional. Default: EncodingText
+	Encoding Encoding
+
 	// TimeFormat https://programming.guide/go/format-parse-string-time-date-example.html
 	//
 	// Optional. Default: 15:04:05
@@ -105,7 +151,9 @@ var ConfigDefault = Config{
 	Next:              nil,
 	Skip:              nil,
 	Done:              nil,
+	Sampler:           nil,
 	Format:            defaultFormat,
+	Encoding:          EncodingText,
 	TimeFormat:        "15:04:05",
 	TimeZone:          "Local",
 	TimeInterval:      500 * time.Millisecond,
@@ -138,9 +186,15 @@ func configDefault(config ...Config) Config {
 	if cfg.Done == nil {
 		cfg.Done = ConfigDefault.Done
 	}
+	if cfg.Sampler == nil {
+		cfg.Sampler = AlwaysSampler()
+	}
 	if cfg.Format == "" {
 		cfg.Format = ConfigDefault.Format
 	}
+	if cfg.Encoding == "" {
+		cfg.Encoding = ConfigDefault.Encoding
+	}
 	if cfg.TimeZone == "" {
 		cfg.TimeZone = ConfigDefault.TimeZone
 	}
@@ -154,6 +208,12 @@ func configDefault(config ...Config) Config {
 		cfg.Stream = ConfigDefault.Stream
 	}
 
+	loc, err := time.LoadLocation(cfg.TimeZone)
+	if err != nil {
+		loc = time.Local
+	}
+	cfg.timeZoneLocation = loc
+
 	if cfg.BeforeHandlerFunc == nil {
 		cfg.BeforeHandlerFunc = ConfigDefault.BeforeHandlerFunc
 	}
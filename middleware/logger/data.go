@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"time"
+)
+
+// Data holds the per-request values collected while a log line is being
+// built. It is populated once per request and passed to Sampler,
+// CustomTags, LoggerFunc and Sink.Filter so none of them need to re-derive
+// values that have already been computed.
+type Data struct {
+	Pid string
+
+	ChainErr error
+
+	Start time.Time
+	Stop  time.Time
+
+	StatusCode int
+	Method     string
+	Path       string
+	IP         string
+
+	// TraceID and SpanID are populated from the OTel span stored in
+	// c.Context(), when present. Empty when no span is active.
+	TraceID string
+	SpanID  string
+
+	// ReqBody and ResBody hold the captured, redacted request/response
+	// bodies backing ${reqbody} and ${resbody}. Populated only when
+	// Config.BodyCapture allowlists the relevant content type; nil
+	// otherwise.
+	ReqBody []byte
+	ResBody []byte
+
+	// timeFormat and timeLocation are cfg.TimeFormat/cfg.timeZoneLocation,
+	// copied onto Data by New so the ${time} tag can format Start without
+	// needing access to Config.
+	timeFormat   string
+	timeLocation *time.Location
+}
+
+// Latency returns the time elapsed between Start and Stop, i.e. the same
+// value the ${latency} tag renders.
+func (d *Data) Latency() time.Duration {
+	return d.Stop.Sub(d.Start)
+}
+
+// Status returns the response status code recorded for this request.
+func (d *Data) Status() int {
+	return d.StatusCode
+}
+
+// Error returns the handler chain error for this request, if any.
+func (d *Data) Error() error {
+	return d.ChainErr
+}
@@ -0,0 +1,142 @@
+package logger
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Encoding defines how a log record is serialized.
+type Encoding string
+
+const (
+	// EncodingText renders Format as a plain-text line (default).
+	EncodingText Encoding = "text"
+	// EncodingJSON renders one JSON object per request.
+	EncodingJSON Encoding = "json"
+	// EncodingLogfmt renders one logfmt (key=value) line per request.
+	EncodingLogfmt Encoding = "logfmt"
+)
+
+// namespacedPrefixes are the tag prefixes whose values are nested under a
+// sub-object instead of being flattened into the top-level record, e.g.
+// ${header:X-Request-Id} becomes {"header":{"X-Request-Id":"..."}}.
+var namespacedPrefixes = map[string]bool{
+	"header": true,
+	"locals": true,
+	"query":  true,
+}
+
+// populateTrace fills data.TraceID/SpanID from the OTel span in c.Context(),
+// if any. Called once per request regardless of Config.Encoding, so typed
+// Data accessors work the same way under every encoding.
+func populateTrace(c fiber.Ctx, data *Data) {
+	span := trace.SpanFromContext(c.Context())
+	if !span.SpanContext().IsValid() {
+		return
+	}
+	data.TraceID = span.SpanContext().TraceID().String()
+	data.SpanID = span.SpanContext().SpanID().String()
+}
+
+// encodeStructured renders data as a JSON or logfmt object, keyed by the
+// same tag names used in cfg.Format, so LoggerFunc implementations for
+// Zap/Zerolog/etc. can switch Encoding without changing their field names.
+func encodeStructured(cfg *Config, c fiber.Ctx, data *Data, values map[string]string) ([]byte, error) {
+	fields := make(map[string]any, len(values)+2)
+	nested := make(map[string]map[string]string)
+
+	for tag, value := range values {
+		name, param, hasParam := strings.Cut(tag, paramSeparator)
+		if hasParam && namespacedPrefixes[name] {
+			ns, ok := nested[name]
+			if !ok {
+				ns = make(map[string]string)
+				nested[name] = ns
+			}
+			ns[param] = value
+			continue
+		}
+		fields[tag] = value
+	}
+	for name, ns := range nested {
+		fields[name] = ns
+	}
+
+	// data.TraceID/SpanID are populated once per request in New,
+	// independent of Encoding, so LoggerFunc implementations can read
+	// them straight off Data even under EncodingText.
+	if data.TraceID != "" {
+		fields["trace_id"] = data.TraceID
+		fields["span_id"] = data.SpanID
+	}
+
+	switch cfg.Encoding {
+	case EncodingLogfmt:
+		return encodeLogfmt(fields), nil
+	default:
+		return json.Marshal(fields)
+	}
+}
+
+// encodeLogfmt renders fields as space-separated key=value pairs, quoting
+// any value that contains whitespace or an equals sign. Nested objects are
+// flattened with a dotted key, e.g. header.X-Request-Id=abc. Keys are
+// sorted so the same fields always render in the same order - map
+// iteration order isn't stable, and json.Marshal already sorts object
+// keys, so logfmt would otherwise be the only encoding that disagreed
+// between two requests with identical fields.
+func encodeLogfmt(fields map[string]any) []byte {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	first := true
+	writePair := func(key string, value any) {
+		if !first {
+			b.WriteByte(' ')
+		}
+		first = false
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(logfmtValue(value))
+	}
+	for _, key := range keys {
+		value := fields[key]
+		if ns, ok := value.(map[string]string); ok {
+			nsKeys := make([]string, 0, len(ns))
+			for k := range ns {
+				nsKeys = append(nsKeys, k)
+			}
+			sort.Strings(nsKeys)
+			for _, k := range nsKeys {
+				writePair(key+"."+k, ns[k])
+			}
+			continue
+		}
+		writePair(key, value)
+	}
+	return []byte(b.String())
+}
+
+func logfmtValue(value any) string {
+	s, ok := value.(string)
+	if !ok {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+	if strings.ContainsAny(s, " \t\r\n=\"") {
+		return strconv.Quote(s)
+	}
+	return s
+}
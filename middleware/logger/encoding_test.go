@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestPopulateTrace_SetOnDataRegardlessOfEncoding(t *testing.T) {
+	var got Data
+
+	app := fiber.New()
+	app.Use(New(Config{
+		Encoding: EncodingText, // default - not JSON/logfmt
+		LoggerFunc: func(c fiber.Ctx, data *Data, cfg Config) error {
+			got = *data
+			return nil
+		},
+	}))
+	app.Get("/", func(c fiber.Ctx) error {
+		sc := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    [16]byte{1},
+			SpanID:     [8]byte{1},
+			TraceFlags: trace.FlagsSampled,
+		})
+		c.SetContext(trace.ContextWithSpanContext(c.Context(), sc))
+		return c.SendString("ok")
+	})
+
+	if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil)); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if got.TraceID == "" || got.SpanID == "" {
+		t.Fatalf("trace_id/span_id not populated under EncodingText: %+v", got)
+	}
+}
+
+func TestEncodeLogfmt_Deterministic(t *testing.T) {
+	fields := map[string]any{
+		"status": "200",
+		"method": "GET",
+		"path":   "/x",
+		"header": map[string]string{"b": "2", "a": "1"},
+	}
+	first := string(encodeLogfmt(fields))
+	for i := 0; i < 10; i++ {
+		if got := string(encodeLogfmt(fields)); got != first {
+			t.Fatalf("encodeLogfmt not deterministic: %q != %q", got, first)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// New creates a new logger middleware handler using the provided config.
+func New(config ...Config) fiber.Handler {
+	cfg := configDefault(config...)
+	cfg.BeforeHandlerFunc(cfg)
+
+	var setupAsync sync.Once
+
+	return func(c fiber.Ctx) error {
+		if cfg.Next != nil && cfg.Next(c) {
+			return c.Next()
+		}
+
+		// Async wraps cfg.Stream (shared by every call of this handler,
+		// since cfg is closed over) on the first request, once we have a
+		// *fiber.App to register the shutdown flush with - New itself
+		// never gets one.
+		if cfg.Async.Enabled {
+			setupAsync.Do(func() {
+				stream := NewAsyncStream(cfg.Stream, cfg.Async)
+				cfg.Stream = stream
+				c.App().Hooks().OnPreShutdown(func() error {
+					return stream.Close()
+				})
+			})
+		}
+
+		if cfg.Skip != nil && cfg.Skip(c) {
+			return c.Next()
+		}
+
+		data := &Data{
+			Start:        time.Now(),
+			Method:       c.Method(),
+			Path:         c.Path(),
+			IP:           c.IP(),
+			ReqBody:      captureRequestBody(c, cfg.BodyCapture),
+			timeFormat:   cfg.TimeFormat,
+			timeLocation: cfg.timeZoneLocation,
+		}
+
+		chainErr := c.Next()
+
+		data.Stop = time.Now()
+		data.ChainErr = chainErr
+		data.StatusCode = c.Response().StatusCode()
+		data.ResBody = captureResponseBody(c, cfg.BodyCapture)
+		populateTrace(c, data)
+
+		if !shouldForceLog(c, data) && cfg.Sampler != nil && !cfg.Sampler(c, data) {
+			return chainErr
+		}
+
+		if err := cfg.LoggerFunc(c, data, cfg); err != nil {
+			return err
+		}
+
+		return chainErr
+	}
+}
+
+// beforeHandlerFunc is Config's default BeforeHandlerFunc. It runs once
+// when the middleware is registered, before any request is handled.
+func beforeHandlerFunc(_ Config) {}
+
+// defaultLoggerInstance is Config's default LoggerFunc: it renders data
+// through cfg.Sinks (or the single Stream/Format/Encoding sink when Sinks
+// is empty, per resolveSinks) and then runs Done with the plain-text line.
+func defaultLoggerInstance(c fiber.Ctx, data *Data, cfg Config) error {
+	if err := writeSinks(&cfg, c, data); err != nil {
+		return err
+	}
+	if cfg.Done != nil {
+		text, _ := renderFormat(&cfg, compiledFormatFor(cfg.Format), c, data)
+		cfg.Done(c, []byte(text))
+	}
+	return nil
+}
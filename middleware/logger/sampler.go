@@ -0,0 +1,122 @@
+package logger
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// Sampler decides whether the current request should be logged. It runs
+// after Skip (so hard-filtered requests never reach it) and before the log
+// line is formatted, so a request that is sampled out pays no formatting
+// cost. Sampler is never consulted for responses with a 5xx status or when
+// c.Locals("logger.force") is true - both are always logged.
+type Sampler func(c fiber.Ctx, d *Data) bool
+
+// SamplingRule samples requests matching Method/StatusClass/Route at
+// Fraction. An empty Method, StatusClass or Route matches any value for
+// that field. Rules are evaluated in order and the first match wins.
+type SamplingRule struct {
+	// Method restricts the rule to one HTTP method, e.g. "GET".
+	Method string
+
+	// StatusClass restricts the rule to a status class: "2xx", "3xx",
+	// "4xx" or "5xx".
+	StatusClass string
+
+	// Route restricts the rule to a registered route pattern, as returned
+	// by c.Route().Path, e.g. "/api/:id".
+	Route string
+
+	// Fraction is the portion of matching requests to keep, in [0, 1].
+	Fraction float64
+}
+
+// AlwaysSampler logs every request that reaches it.
+func AlwaysSampler() Sampler {
+	return func(_ fiber.Ctx, _ *Data) bool {
+		return true
+	}
+}
+
+// RateSampler keeps a uniform fraction of requests, in [0, 1]. Requests are
+// sampled deterministically by hashing a correlation key (see sampleKey) so
+// that every log line produced for the same request makes the same
+// decision, rather than flipping a coin per request.
+func RateSampler(fraction float64) Sampler {
+	return func(c fiber.Ctx, d *Data) bool {
+		return sampleAt(c, d, fraction)
+	}
+}
+
+// RuleSampler samples requests according to the first matching rule in
+// rules. A request matching no rule is logged.
+func RuleSampler(rules []SamplingRule) Sampler {
+	return func(c fiber.Ctx, d *Data) bool {
+		for _, rule := range rules {
+			if !rule.matches(c, d) {
+				continue
+			}
+			return sampleAt(c, d, rule.Fraction)
+		}
+		return true
+	}
+}
+
+func (r SamplingRule) matches(c fiber.Ctx, d *Data) bool {
+	if r.Method != "" && !strings.EqualFold(r.Method, d.Method) {
+		return false
+	}
+	if r.Route != "" && r.Route != c.Route().Path {
+		return false
+	}
+	if r.StatusClass != "" && statusClass(d.StatusCode) != r.StatusClass {
+		return false
+	}
+	return true
+}
+
+func statusClass(status int) string {
+	return strconv.Itoa(status/100) + "xx"
+}
+
+// shouldForceLog reports whether a request must bypass Sampler entirely:
+// 5xx responses and anything marked with c.Locals("logger.force").
+func shouldForceLog(c fiber.Ctx, d *Data) bool {
+	if d.StatusCode >= 500 {
+		return true
+	}
+	forced, _ := c.Locals("logger.force").(bool)
+	return forced
+}
+
+// sampleAt hashes the request's correlation key into [0, 1) and compares it
+// against fraction, so repeated log lines for the same request (access log,
+// audit log, ...) agree on whether to keep it.
+func sampleAt(c fiber.Ctx, d *Data, fraction float64) bool {
+	if fraction >= 1 {
+		return true
+	}
+	if fraction <= 0 {
+		return false
+	}
+	return sampleKeyRatio(sampleKey(c, d)) < fraction
+}
+
+// sampleKey returns the value requests are correlated on: the request ID
+// local when set, otherwise the remote IP and the request start time.
+func sampleKey(c fiber.Ctx, d *Data) string {
+	if id, ok := c.Locals("requestid").(string); ok && id != "" {
+		return id
+	}
+	return d.IP + "|" + d.Start.String()
+}
+
+// sampleKeyRatio hashes key to a float in [0, 1), uniformly distributed.
+func sampleKeyRatio(key string) float64 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return float64(h.Sum32()) / float64(1<<32)
+}
@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestSampler_DropsUnsampledRequests(t *testing.T) {
+	var writes int
+
+	app := fiber.New()
+	app.Use(New(Config{
+		Stream: writerFunc(func(p []byte) (int, error) {
+			writes++
+			return len(p), nil
+		}),
+		Sampler: RateSampler(0),
+	}))
+	app.Get("/", func(c fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusOK)
+	}
+	if writes != 0 {
+		t.Fatalf("writes = %d, want 0 (request should have been sampled out)", writes)
+	}
+}
+
+func TestSampler_AlwaysKeeps5xxRegardlessOfSampler(t *testing.T) {
+	var writes int
+
+	app := fiber.New()
+	app.Use(New(Config{
+		Stream: writerFunc(func(p []byte) (int, error) {
+			writes++
+			return len(p), nil
+		}),
+		Sampler: RateSampler(0),
+	}))
+	app.Get("/", func(c fiber.Ctx) error {
+		return c.Status(fiber.StatusInternalServerError).SendString("boom")
+	})
+
+	if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil)); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if writes != 1 {
+		t.Fatalf("writes = %d, want 1 (5xx must bypass Sampler)", writes)
+	}
+}
+
+func TestSampler_AlwaysKeepsForcedLocal(t *testing.T) {
+	var writes int
+
+	app := fiber.New()
+	app.Use(New(Config{
+		Stream: writerFunc(func(p []byte) (int, error) {
+			writes++
+			return len(p), nil
+		}),
+		Sampler: RateSampler(0),
+	}))
+	app.Get("/", func(c fiber.Ctx) error {
+		c.Locals("logger.force", true)
+		return c.SendString("ok")
+	})
+
+	if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil)); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if writes != 1 {
+		t.Fatalf("writes = %d, want 1 (logger.force must bypass Sampler)", writes)
+	}
+}
+
+func TestRuleSampler_MatchesFirstRule(t *testing.T) {
+	rules := []SamplingRule{
+		{StatusClass: "4xx", Fraction: 0},
+		{Fraction: 1},
+	}
+	sampler := RuleSampler(rules)
+
+	d := &Data{StatusCode: fiber.StatusNotFound, IP: "10.0.0.1"}
+	if sampler(nil, d) {
+		t.Fatalf("4xx rule should have dropped the request")
+	}
+}
+
+// writerFunc adapts a function to an io.Writer, for assertions on what
+// gets written without needing a real stream.
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) { return f(p) }
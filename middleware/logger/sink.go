@@ -0,0 +1,234 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// Sink is one destination a log line may be routed to. A single middleware
+// instance can fan out to several sinks - e.g. a compact text stream to
+// stdout, a JSON stream to a file for shipping, and a 5xx-only stream to an
+// alerting webhook - instead of stacking multiple logger middlewares.
+type Sink struct {
+	// Stream is where this sink's formatted log line is written.
+	Stream io.Writer
+
+	// Format overrides Config.Format for this sink. Empty uses the
+	// enclosing Config.Format.
+	//
+	// Optional. Default: ""
+	Format string
+
+	// Encoding overrides Config.Encoding for this sink. Empty uses the
+	// enclosing Config.Encoding.
+	//
+	// Optional. Default: ""
+	Encoding Encoding
+
+	// MinStatus and MaxStatus restrict this sink to a status range,
+	// inclusive. Zero values mean "no bound" (0 and 599 respectively).
+	//
+	// Optional. Default: 0, 0 (no bound)
+	MinStatus int
+	MaxStatus int
+
+	// Filter further restricts which requests reach this sink, on top of
+	// MinStatus/MaxStatus.
+	//
+	// Optional. Default: nil
+	Filter func(c fiber.Ctx, d *Data) bool
+}
+
+// matches reports whether data's status and this sink's Filter admit the
+// current request.
+func (s Sink) matches(c fiber.Ctx, data *Data) bool {
+	minStatus, maxStatus := s.MinStatus, s.MaxStatus
+	if maxStatus == 0 {
+		maxStatus = 599
+	}
+	if data.StatusCode < minStatus || data.StatusCode > maxStatus {
+		return false
+	}
+	return s.Filter == nil || s.Filter(c, data)
+}
+
+// resolveSinks returns cfg.Sinks, or - when none are configured - a single
+// Sink built from cfg.Stream/cfg.Format/cfg.Encoding, so a plain Config
+// behaves exactly as it did before Sinks existed.
+func resolveSinks(cfg *Config) []Sink {
+	if len(cfg.Sinks) > 0 {
+		return cfg.Sinks
+	}
+	return []Sink{{Stream: cfg.Stream, Format: cfg.Format, Encoding: cfg.Encoding}}
+}
+
+// writeSinks renders data against every sink that matches the current
+// request and writes the result to that sink's Stream.
+func writeSinks(cfg *Config, c fiber.Ctx, data *Data) error {
+	var errs []error
+	for _, sink := range resolveSinks(cfg) {
+		if !sink.matches(c, data) {
+			continue
+		}
+		if err := writeSink(cfg, sink, c, data); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func writeSink(cfg *Config, sink Sink, c fiber.Ctx, data *Data) error {
+	format := sink.Format
+	if format == "" {
+		format = cfg.Format
+	}
+	encoding := sink.Encoding
+	if encoding == "" {
+		encoding = cfg.Encoding
+	}
+
+	text, values := renderFormat(cfg, compiledFormatFor(format), c, data)
+	if encoding == "" || encoding == EncodingText {
+		_, err := io.WriteString(sink.Stream, text)
+		return err
+	}
+	sinkCfg := *cfg
+	sinkCfg.Encoding = encoding
+	out, err := encodeStructured(&sinkCfg, c, data, values)
+	if err != nil {
+		return err
+	}
+	_, err = sink.Stream.Write(append(out, '\n'))
+	return err
+}
+
+// formatSegment is either a literal run of text, or a ${tag:param} tag.
+type formatSegment struct {
+	literal string
+	tag     string
+	param   string
+}
+
+// compiledFormat is a Format string tokenized once into segments, so the
+// same ${...} template isn't re-parsed on every request for every sink
+// that shares it.
+type compiledFormat struct {
+	segments []formatSegment
+}
+
+var formatCache sync.Map // string -> *compiledFormat
+
+// compiledFormatFor returns the compiledFormat for format, parsing and
+// caching it on first use.
+func compiledFormatFor(format string) *compiledFormat {
+	if v, ok := formatCache.Load(format); ok {
+		return v.(*compiledFormat)
+	}
+	actual, _ := formatCache.LoadOrStore(format, compileFormat(format))
+	return actual.(*compiledFormat)
+}
+
+// compileFormat tokenizes format into literal and tag segments using the
+// same startTag/endTag/paramSeparator markers the rest of the package
+// recognizes.
+func compileFormat(format string) *compiledFormat {
+	var segments []formatSegment
+	rest := format
+	for {
+		start := strings.Index(rest, startTag)
+		if start == -1 {
+			if rest != "" {
+				segments = append(segments, formatSegment{literal: rest})
+			}
+			break
+		}
+		if start > 0 {
+			segments = append(segments, formatSegment{literal: rest[:start]})
+		}
+		rest = rest[start+len(startTag):]
+
+		end := strings.Index(rest, endTag)
+		if end == -1 {
+			segments = append(segments, formatSegment{literal: startTag + rest})
+			break
+		}
+		name, param, _ := strings.Cut(rest[:end], paramSeparator)
+		segments = append(segments, formatSegment{tag: name, param: param})
+		rest = rest[end+len(endTag):]
+	}
+	return &compiledFormat{segments: segments}
+}
+
+// builtinTagFunctions holds the tag functions this package contributes
+// beyond the core set in tags.go (e.g. reqbody/resbody from BodyCapture).
+var builtinTagFunctions = map[string]LogFunc{
+	"reqbody": tagReqBody,
+	"resbody": tagResBody,
+}
+
+func lookupTagFunc(cfg *Config, name string) LogFunc {
+	if fn, ok := cfg.CustomTags[name]; ok {
+		return fn
+	}
+	if fn, ok := builtinTagFunctions[name]; ok {
+		return fn
+	}
+	if fn, ok := tagFunctions[name]; ok {
+		return fn
+	}
+	return nil
+}
+
+// sinkBuffer is a minimal Buffer implementation for evaluating a single tag
+// function's output during rendering.
+type sinkBuffer struct {
+	bytes.Buffer
+}
+
+func (b *sinkBuffer) Set(p []byte) {
+	b.Reset()
+	b.Write(p) //nolint:errcheck // bytes.Buffer.Write never returns an error
+}
+
+func (b *sinkBuffer) SetString(s string) {
+	b.Reset()
+	b.WriteString(s) //nolint:errcheck // bytes.Buffer.WriteString never returns an error
+}
+
+// renderFormat evaluates compiled against data, returning both the
+// plain-text line (for EncodingText) and a tag-name->value map (for
+// EncodingJSON/EncodingLogfmt).
+func renderFormat(cfg *Config, compiled *compiledFormat, c fiber.Ctx, data *Data) (string, map[string]string) {
+	var text strings.Builder
+	values := make(map[string]string, len(compiled.segments))
+	buf := new(sinkBuffer)
+
+	for _, seg := range compiled.segments {
+		if seg.tag == "" {
+			text.WriteString(seg.literal)
+			continue
+		}
+		fn := lookupTagFunc(cfg, seg.tag)
+		if fn == nil {
+			continue
+		}
+		buf.Reset()
+		if _, err := fn(buf, c, data, seg.param); err != nil {
+			continue
+		}
+		value := buf.String()
+		text.WriteString(value)
+
+		key := seg.tag
+		if seg.param != "" {
+			key = seg.tag + paramSeparator + seg.param
+		}
+		values[key] = value
+	}
+	return text.String(), values
+}
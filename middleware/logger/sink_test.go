@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestSinks_RoutesByStatusRange(t *testing.T) {
+	var all, errors strings.Builder
+
+	app := fiber.New()
+	app.Use(New(Config{
+		Format: "${status} ${path}\n",
+		Sinks: []Sink{
+			{Stream: &all},
+			{Stream: &errors, MinStatus: 500},
+		},
+	}))
+	app.Get("/ok", func(c fiber.Ctx) error { return c.SendString("ok") })
+	app.Get("/boom", func(c fiber.Ctx) error {
+		return c.Status(fiber.StatusInternalServerError).SendString("boom")
+	})
+
+	if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/ok", nil)); err != nil {
+		t.Fatalf("app.Test /ok: %v", err)
+	}
+	if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/boom", nil)); err != nil {
+		t.Fatalf("app.Test /boom: %v", err)
+	}
+
+	if !strings.Contains(all.String(), "200 /ok") || !strings.Contains(all.String(), "500 /boom") {
+		t.Fatalf("all sink missing a line: %q", all.String())
+	}
+	if strings.Contains(errors.String(), "200 /ok") {
+		t.Fatalf("errors sink should not see 2xx: %q", errors.String())
+	}
+	if !strings.Contains(errors.String(), "500 /boom") {
+		t.Fatalf("errors sink missing 5xx line: %q", errors.String())
+	}
+}
+
+func TestResolveSinks_FallsBackToStream(t *testing.T) {
+	var buf strings.Builder
+	cfg := Config{Stream: &buf, Format: defaultFormat, Encoding: EncodingText}
+
+	sinks := resolveSinks(&cfg)
+	if len(sinks) != 1 {
+		t.Fatalf("len(sinks) = %d, want 1", len(sinks))
+	}
+	if sinks[0].Stream != &buf {
+		t.Fatalf("fallback sink did not reuse cfg.Stream")
+	}
+}
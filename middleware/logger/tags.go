@@ -0,0 +1,96 @@
+package logger
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// tagFunctions holds the LogFunc implementation for every base tag
+// documented on defaultFormat and namespacedPrefixes. builtinTagFunctions
+// and cfg.CustomTags are checked first (see lookupTagFunc), so a caller can
+// still shadow any of these.
+var tagFunctions = map[string]LogFunc{
+	"time":    tagTime,
+	"ip":      tagIP,
+	"status":  tagStatus,
+	"latency": tagLatency,
+	"method":  tagMethod,
+	"path":    tagPath,
+	"error":   tagError,
+	"header":  tagHeader,
+	"locals":  tagLocals,
+	"query":   tagQuery,
+}
+
+// tagTime implements ${time}, formatting data.Start with the TimeFormat and
+// TimeZone resolved onto data by New. Falls back to RFC3339 in Local time
+// when data wasn't built by New (e.g. a tag function exercised directly in
+// a test).
+func tagTime(output Buffer, _ fiber.Ctx, data *Data, _ string) (int, error) {
+	format := data.timeFormat
+	if format == "" {
+		format = time.RFC3339
+	}
+	loc := data.timeLocation
+	if loc == nil {
+		loc = time.Local
+	}
+	return output.WriteString(data.Start.In(loc).Format(format))
+}
+
+// tagIP implements ${ip}.
+func tagIP(output Buffer, _ fiber.Ctx, data *Data, _ string) (int, error) {
+	return output.WriteString(data.IP)
+}
+
+// tagStatus implements ${status}.
+func tagStatus(output Buffer, _ fiber.Ctx, data *Data, _ string) (int, error) {
+	return output.WriteString(strconv.Itoa(data.StatusCode))
+}
+
+// tagLatency implements ${latency}, the duration between Start and Stop.
+func tagLatency(output Buffer, _ fiber.Ctx, data *Data, _ string) (int, error) {
+	return output.WriteString(data.Latency().String())
+}
+
+// tagMethod implements ${method}.
+func tagMethod(output Buffer, _ fiber.Ctx, data *Data, _ string) (int, error) {
+	return output.WriteString(data.Method)
+}
+
+// tagPath implements ${path}.
+func tagPath(output Buffer, _ fiber.Ctx, data *Data, _ string) (int, error) {
+	return output.WriteString(data.Path)
+}
+
+// tagError implements ${error}, rendering the handler chain error if any,
+// or an empty string otherwise.
+func tagError(output Buffer, _ fiber.Ctx, data *Data, _ string) (int, error) {
+	if data.ChainErr == nil {
+		return 0, nil
+	}
+	return output.WriteString(data.ChainErr.Error())
+}
+
+// tagHeader implements ${header:<name>}, reading the named request header.
+func tagHeader(output Buffer, c fiber.Ctx, _ *Data, extraParam string) (int, error) {
+	return output.WriteString(c.Get(extraParam))
+}
+
+// tagLocals implements ${locals:<key>}, rendering the local's fmt.Sprint
+// representation, or an empty string when unset.
+func tagLocals(output Buffer, c fiber.Ctx, _ *Data, extraParam string) (int, error) {
+	val := c.Locals(extraParam)
+	if val == nil {
+		return 0, nil
+	}
+	return output.WriteString(fmt.Sprint(val))
+}
+
+// tagQuery implements ${query:<name>}, reading the named query parameter.
+func tagQuery(output Buffer, c fiber.Ctx, _ *Data, extraParam string) (int, error) {
+	return output.WriteString(c.Query(extraParam))
+}
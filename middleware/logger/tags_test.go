@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestTagFunctions_RenderDefaultFormat(t *testing.T) {
+	var out strings.Builder
+
+	app := fiber.New()
+	app.Use(New(Config{Stream: &out, Format: defaultFormat}))
+	app.Get("/x", func(c fiber.Ctx) error { return c.SendString("ok") })
+
+	if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/x", nil)); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+
+	line := out.String()
+	for _, want := range []string{"GET", "/x", "200"} {
+		if !strings.Contains(line, want) {
+			t.Fatalf("line = %q, want it to contain %q", line, want)
+		}
+	}
+	if strings.Contains(line, "[] -  \n") {
+		t.Fatalf("line rendered as if no tag had an implementation: %q", line)
+	}
+}
+
+func TestTagTime_UsesDataTimeFormatAndLocation(t *testing.T) {
+	data := &Data{
+		Start:        time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		timeFormat:   "15:04:05",
+		timeLocation: time.UTC,
+	}
+	buf := new(sinkBuffer)
+	if _, err := tagTime(buf, nil, data, ""); err != nil {
+		t.Fatalf("tagTime: %v", err)
+	}
+	if got := buf.String(); got != "03:04:05" {
+		t.Fatalf("tagTime = %q, want %q", got, "03:04:05")
+	}
+}
+
+func TestTagQueryAndHeader(t *testing.T) {
+	var out strings.Builder
+
+	app := fiber.New()
+	app.Use(New(Config{Stream: &out, Format: "${query:name} ${header:X-Req-Id}\n"}))
+	app.Get("/x", func(c fiber.Ctx) error { return c.SendString("ok") })
+
+	req := httptest.NewRequest(fiber.MethodGet, "/x?name=bob", nil)
+	req.Header.Set("X-Req-Id", "abc123")
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if got := out.String(); got != "bob abc123\n" {
+		t.Fatalf("line = %q, want %q", got, "bob abc123\n")
+	}
+}